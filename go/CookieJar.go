@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"sync"
+)
+
+// errCookieJarDisabled 在调用Cookies/SetCookies/SaveCookieJar/LoadCookieJar时，
+// 如果创建HttpClient时未设置EnableCookieJar，统一返回此错误
+var errCookieJarDisabled = errors.New("未启用CookieJar，请在HttpClientOption中设置EnableCookieJar")
+
+// cookieJarSnapshot 是某个host当前持有Cookie的可JSON序列化快照
+type cookieJarSnapshot struct {
+	Scheme  string         `json:"scheme"`
+	Host    string         `json:"host"`
+	Cookies []*http.Cookie `json:"cookies"`
+}
+
+// cookieJar 包装net/http/cookiejar.Jar，额外按host记录快照；
+// 标准库cookiejar不支持遍历其中已存储的全部Cookie，SaveCookieJar/LoadCookieJar依赖这份快照。
+// http.Client对同一个Jar的调用可能来自并发请求，storeMu保护store这份旁路索引
+type cookieJar struct {
+	jar     *cookiejar.Jar
+	storeMu sync.Mutex
+	store   map[string]*cookieJarSnapshot
+}
+
+// newCookieJar 创建一个空的cookieJar，cookiejar.New(nil)在不传Options时不会返回error
+func newCookieJar() *cookieJar {
+	jar, _ := cookiejar.New(nil)
+	return &cookieJar{jar: jar, store: make(map[string]*cookieJarSnapshot)}
+}
+
+// SetCookies 实现http.CookieJar接口，同时刷新该host的持久化快照；
+// http.Client在并发请求下会从多个goroutine调用它，因此对store的写入加锁
+func (j *cookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+
+	snapshot := &cookieJarSnapshot{Scheme: u.Scheme, Host: u.Host, Cookies: j.jar.Cookies(u)}
+	j.storeMu.Lock()
+	j.store[u.Host] = snapshot
+	j.storeMu.Unlock()
+}
+
+// Cookies 实现http.CookieJar接口
+func (j *cookieJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// snapshots 返回store中所有快照的副本，供SaveCookieJar做JSON持久化
+func (j *cookieJar) snapshots() []*cookieJarSnapshot {
+	j.storeMu.Lock()
+	defer j.storeMu.Unlock()
+
+	snapshots := make([]*cookieJarSnapshot, 0, len(j.store))
+	for _, snapshot := range j.store {
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+// Cookies 返回指定URL当前持有的Cookie，需先在HttpClientOption中设置EnableCookieJar
+func (c *HttpClient) Cookies(rawURL string) ([]*http.Cookie, error) {
+	jar, ok := c.client.Jar.(*cookieJar)
+	if !ok {
+		return nil, errCookieJarDisabled
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return jar.Cookies(u), nil
+}
+
+// SetCookies 手动向CookieJar写入指定URL的Cookie，常用于恢复一个已知的登录态
+func (c *HttpClient) SetCookies(rawURL string, cookies []*http.Cookie) error {
+	jar, ok := c.client.Jar.(*cookieJar)
+	if !ok {
+		return errCookieJarDisabled
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	jar.SetCookies(u, cookies)
+	return nil
+}
+
+// SaveCookieJar 将每个host的快照序列化为JSON写入path，用于跨进程保留会话；
+// 每个host只保存最近一次SetCookies时该URL可见的Cookie，且只含名称与值（Path、Expires等属性不保存）
+func (c *HttpClient) SaveCookieJar(path string) error {
+	jar, ok := c.client.Jar.(*cookieJar)
+	if !ok {
+		return errCookieJarDisabled
+	}
+
+	data, err := json.Marshal(jar.snapshots())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// LoadCookieJar 从path读取之前SaveCookieJar保存的Cookie并写回CookieJar
+func (c *HttpClient) LoadCookieJar(path string) error {
+	jar, ok := c.client.Jar.(*cookieJar)
+	if !ok {
+		return errCookieJarDisabled
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var snapshots []*cookieJarSnapshot
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return err
+	}
+
+	for _, snapshot := range snapshots {
+		u := &url.URL{Scheme: snapshot.Scheme, Host: snapshot.Host}
+		jar.SetCookies(u, snapshot.Cookies)
+	}
+
+	return nil
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"bytes"
+	"compress/flate"
+	"compress/gzip"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,9 +25,16 @@ type HttpClientOption struct {
 	SocketTimeout            int // 毫秒
 	ConnectTimeout           int // 毫秒
 	IgnoreSSL                bool
-	defaultSocketTimeout     int // 秒
-	defaultConnectTimeout    int // 秒
-	connectionRequestTimeout int // 秒
+	DisableCompression       bool     // true则不默认发送Accept-Encoding，也不自动解压响应体
+	ClientCertPEM            []byte   // PEM编码的客户端证书，用于mTLS，须与ClientKeyPEM配合使用
+	ClientKeyPEM             []byte   // PEM编码的客户端私钥
+	RootCAs                  [][]byte // PEM编码的自定义CA证书，追加到系统信任池用于校验服务端证书
+	MinTLSVersion            uint16   // 对应tls.VersionTLS12等常量，0使用Go默认最小版本
+	MaxTLSVersion            uint16   // 对应tls.VersionTLS13等常量，0使用Go默认最大版本
+	EnableCookieJar          bool     // true则启用CookieJar自动捕获Set-Cookie，使同一HttpClient的多次请求共享会话
+	defaultSocketTimeout     int      // 秒
+	defaultConnectTimeout    int      // 秒
+	connectionRequestTimeout int      // 秒
 }
 
 // NewHttpClientOption 创建默认的HTTP客户端配置
@@ -64,14 +74,33 @@ func (r *HTTPResponse) String() string {
 		r.StatusCode, r.ElapsedTime, len(r.Content))
 }
 
+// RequestInterceptor 请求发出前的拦截器，可用于签名、追踪头注入、请求日志等；返回error将中断请求
+type RequestInterceptor func(*http.Request) error
+
+// ResponseInterceptor 响应返回调用方前的拦截器，可用于响应校验、日志、脱敏等；返回error将中断返回
+type ResponseInterceptor func(*HTTPResponse) error
+
 // HttpClient HTTP客户端
 type HttpClient struct {
-	option *HttpClientOption
-	client *http.Client
+	option               *HttpClientOption
+	client               *http.Client
+	requestInterceptors  []RequestInterceptor
+	responseInterceptors []ResponseInterceptor
+}
+
+// AddRequestInterceptor 注册请求拦截器，按注册顺序依次执行
+func (c *HttpClient) AddRequestInterceptor(interceptor RequestInterceptor) {
+	c.requestInterceptors = append(c.requestInterceptors, interceptor)
+}
+
+// AddResponseInterceptor 注册响应拦截器，按注册顺序依次执行
+func (c *HttpClient) AddResponseInterceptor(interceptor ResponseInterceptor) {
+	c.responseInterceptors = append(c.responseInterceptors, interceptor)
 }
 
-// NewHttpClient 创建HTTP客户端
-func NewHttpClient(option *HttpClientOption) *HttpClient {
+// NewHttpClient 创建HTTP客户端；ClientCertPEM/ClientKeyPEM配对无效时返回error而不是panic，
+// 因为这些通常来自外部配置/文件加载，不应该让调用方的进程崩溃
+func NewHttpClient(option *HttpClientOption) (*HttpClient, error) {
 	if option == nil {
 		option = NewHttpClientOption()
 	}
@@ -106,28 +135,51 @@ func NewHttpClient(option *HttpClientOption) *HttpClient {
 		MaxConnsPerHost:       20,
 	}
 
-	// 配置代理
-	if option.ProxyAddress != "" {
-		proxyURL, err := url.Parse("http://" + option.ProxyAddress)
+	// 配置代理：支持http(s)/socks5(h)://，为空时退回系统代理环境变量
+	if err := configureProxy(transport, option.ProxyAddress); err != nil {
+		fmt.Printf("配置代理失败，将不使用代理: %v\n", err)
+	}
+
+	// 配置TLS：证书校验、mTLS客户端证书、自定义CA、版本范围
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: option.IgnoreSSL,
+		MinVersion:         option.MinTLSVersion,
+		MaxVersion:         option.MaxTLSVersion,
+	}
+
+	if len(option.ClientCertPEM) > 0 && len(option.ClientKeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(option.ClientCertPEM, option.ClientKeyPEM)
 		if err != nil {
-			panic(fmt.Sprintf("无效的代理地址: %v", err))
+			return nil, fmt.Errorf("加载客户端证书失败: %w", err)
 		}
-		transport.Proxy = http.ProxyURL(proxyURL)
+		tlsConfig.Certificates = []tls.Certificate{cert}
 	}
 
-	// 配置SSL验证
-	if option.IgnoreSSL {
-		transport.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true,
+	if len(option.RootCAs) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, ca := range option.RootCAs {
+			pool.AppendCertsFromPEM(ca)
 		}
+		tlsConfig.RootCAs = pool
 	}
 
+	transport.TLSClientConfig = tlsConfig
+	// 关闭Transport自带的gzip协商与解压，否则DisableCompression时它仍会发送Accept-Encoding并解压响应
+	transport.DisableCompression = option.DisableCompression
+
 	client.Transport = transport
 
+	if option.EnableCookieJar {
+		client.Jar = newCookieJar()
+	}
+
 	return &HttpClient{
 		option: option,
 		client: client,
-	}
+	}, nil
 }
 
 // buildRequest 构建基础请求
@@ -149,6 +201,11 @@ func (c *HttpClient) buildRequest(method, urlStr string, body io.Reader, headers
 		}
 	}
 
+	// 默认声明支持gzip/deflate，doRequest会据此自动解压；调用方手动设置过Accept-Encoding则不覆盖
+	if !c.option.DisableCompression && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+	}
+
 	// 设置Cookie
 	for k, v := range c.option.Cookie {
 		req.AddCookie(&http.Cookie{Name: k, Value: v})
@@ -159,6 +216,12 @@ func (c *HttpClient) buildRequest(method, urlStr string, body io.Reader, headers
 
 // doRequest 执行请求并返回响应
 func (c *HttpClient) doRequest(req *http.Request) (*HTTPResponse, error) {
+	for _, interceptor := range c.requestInterceptors {
+		if err := interceptor(req); err != nil {
+			return nil, err
+		}
+	}
+
 	startTime := time.Now()
 
 	resp, err := c.client.Do(req)
@@ -167,8 +230,30 @@ func (c *HttpClient) doRequest(req *http.Request) (*HTTPResponse, error) {
 	}
 	defer resp.Body.Close()
 
+	// 按Content-Encoding透明解压，DisableCompression时保留原始字节；
+	// decoded只在实际解压时置true，未识别的编码（如br）原样保留Content-Encoding头
+	reader := resp.Body
+	contentEncoding := strings.ToLower(resp.Header.Get("Content-Encoding"))
+	decoded := false
+	if !c.option.DisableCompression {
+		switch contentEncoding {
+		case "gzip":
+			gzReader, gzErr := gzip.NewReader(resp.Body)
+			if gzErr != nil {
+				return nil, gzErr
+			}
+			defer gzReader.Close()
+			reader = gzReader
+			decoded = true
+		case "deflate":
+			reader = flate.NewReader(resp.Body)
+			defer reader.Close()
+			decoded = true
+		}
+	}
+
 	// 读取响应内容
-	content, err := io.ReadAll(resp.Body)
+	content, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, err
 	}
@@ -176,14 +261,52 @@ func (c *HttpClient) doRequest(req *http.Request) (*HTTPResponse, error) {
 	// 计算耗时(毫秒)
 	elapsedTime := float64(time.Since(startTime).Microseconds()) / 1000
 
-	return &HTTPResponse{
+	// 内容已解压，Content-Encoding/Content-Length头不再反映实际Content，从响应头中剔除；
+	// 未被上面的switch处理的编码（如br）没有解压，Content仍是压缩字节，保留头以免误导调用方
+	headers := make(map[string][]string, len(resp.Header))
+	for k, v := range resp.Header {
+		headers[k] = v
+	}
+	if decoded {
+		delete(headers, "Content-Encoding")
+		delete(headers, "Content-Length")
+	}
+
+	httpResp := &HTTPResponse{
 		RequestMethod: req.Method,
 		RequestURL:    req.URL.String(),
 		StatusCode:    resp.StatusCode,
-		Headers:       resp.Header,
+		Headers:       headers,
 		Content:       content,
 		ElapsedTime:   elapsedTime,
-	}, nil
+	}
+
+	for _, interceptor := range c.responseInterceptors {
+		if err := interceptor(httpResp); err != nil {
+			return nil, err
+		}
+	}
+
+	return httpResp, nil
+}
+
+// Do 执行一个调用方自行构建的请求，供RequestBuilder之外需要完全掌控请求细节的场景使用
+func (c *HttpClient) Do(req *http.Request) (*HTTPResponse, error) {
+	return c.doRequest(req)
+}
+
+// DoInto 执行请求并将JSON响应体解析到v中，HTTP状态码>=400时返回错误而不解析
+func (c *HttpClient) DoInto(req *http.Request, v interface{}) error {
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Text())
+	}
+
+	return resp.JSON(v)
 }
 
 // Get 发送GET请求
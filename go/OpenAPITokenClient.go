@@ -3,13 +3,16 @@ package main
 import (
 	"bytes"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,34 +23,98 @@ type TokenHttpClientOption struct {
 	ConnectTimeout int
 	IgnoreSSL      bool
 	ProxyAddress   string
+	ClientCertPEM  []byte   // PEM编码的客户端证书，用于mTLS，须与ClientKeyPEM配合使用
+	ClientKeyPEM   []byte   // PEM编码的客户端私钥
+	RootCAs        [][]byte // PEM编码的自定义CA证书，追加到系统信任池用于校验服务端证书
+	MinTLSVersion  uint16   // 对应tls.VersionTLS12等常量，0使用Go默认最小版本
+	MaxTLSVersion  uint16   // 对应tls.VersionTLS13等常量，0使用Go默认最大版本
 }
 
+// TokenRequestInterceptor 请求发出前的拦截器，返回error将中断请求
+type TokenRequestInterceptor func(*http.Request) error
+
+// TokenResponseInterceptor 响应返回调用方前的拦截器，返回error将中断返回
+type TokenResponseInterceptor func(*http.Response) error
+
 // TokenHttpClient HTTP客户端
 type TokenHttpClient struct {
-	client *http.Client
-	header map[string]string
+	client               *http.Client
+	headerMu             sync.RWMutex // 保护header，RefreshToken等场景会在请求进行中并发改写它
+	header               map[string]string
+	requestInterceptors  []TokenRequestInterceptor
+	responseInterceptors []TokenResponseInterceptor
+}
+
+// AddRequestInterceptor 注册请求拦截器，按注册顺序依次执行
+func (c *TokenHttpClient) AddRequestInterceptor(interceptor TokenRequestInterceptor) {
+	c.requestInterceptors = append(c.requestInterceptors, interceptor)
+}
+
+// AddResponseInterceptor 注册响应拦截器，按注册顺序依次执行
+func (c *TokenHttpClient) AddResponseInterceptor(interceptor TokenResponseInterceptor) {
+	c.responseInterceptors = append(c.responseInterceptors, interceptor)
+}
+
+// send 统一执行请求拦截器->发送->响应拦截器，供各verb方法复用
+func (c *TokenHttpClient) send(req *http.Request) (*http.Response, error) {
+	for _, interceptor := range c.requestInterceptors {
+		if err := interceptor(req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, interceptor := range c.responseInterceptors {
+		if err := interceptor(resp); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
 }
 
-// NewTokenHttpClient 创建新的HTTP客户端
-func NewTokenHttpClient(option TokenHttpClientOption) *TokenHttpClient {
+// NewTokenHttpClient 创建新的HTTP客户端；ClientCertPEM/ClientKeyPEM配对无效时返回error而不是panic，
+// 因为这些通常来自外部配置/文件加载，不应该让调用方的进程崩溃
+func NewTokenHttpClient(option TokenHttpClientOption) (*TokenHttpClient, error) {
 	transport := &http.Transport{}
 
-	// 配置代理
-	if option.ProxyAddress != "" {
-		proxyURL, err := url.Parse(option.ProxyAddress)
-		if err == nil {
-			transport.Proxy = http.ProxyURL(proxyURL)
+	// 配置代理：支持http(s)/socks5(h)://，为空时退回系统代理环境变量
+	if err := configureProxy(transport, option.ProxyAddress); err != nil {
+		fmt.Printf("配置代理失败，将不使用代理: %v\n", err)
+	}
+
+	// 配置TLS：证书校验、mTLS客户端证书、自定义CA、版本范围
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: option.IgnoreSSL,
+		MinVersion:         option.MinTLSVersion,
+		MaxVersion:         option.MaxTLSVersion,
+	}
+
+	if len(option.ClientCertPEM) > 0 && len(option.ClientKeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(option.ClientCertPEM, option.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("加载客户端证书失败: %w", err)
 		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
 	}
 
-	// 配置SSL验证
-	if option.IgnoreSSL {
-		if transport.TLSClientConfig == nil {
-			transport.TLSClientConfig = &tls.Config{}
+	if len(option.RootCAs) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
 		}
-		transport.TLSClientConfig.InsecureSkipVerify = true
+		for _, ca := range option.RootCAs {
+			pool.AppendCertsFromPEM(ca)
+		}
+		tlsConfig.RootCAs = pool
 	}
 
+	transport.TLSClientConfig = tlsConfig
+
 	// 配置超时
 	client := &http.Client{
 		Transport: transport,
@@ -60,7 +127,7 @@ func NewTokenHttpClient(option TokenHttpClientOption) *TokenHttpClient {
 	return &TokenHttpClient{
 		client: client,
 		header: option.Header,
-	}
+	}, nil
 }
 
 // Get 发送GET请求
@@ -73,7 +140,7 @@ func (c *TokenHttpClient) Get(url string, headers map[string]string) (*http.Resp
 	// 设置请求头
 	c.setHeaders(req, headers)
 
-	return c.client.Do(req)
+	return c.send(req)
 }
 
 // PostJSON 发送JSON POST请求
@@ -94,7 +161,7 @@ func (c *TokenHttpClient) PostJSON(url string, data interface{}, headers map[str
 	// 设置请求头
 	c.setHeaders(req, headers)
 
-	return c.client.Do(req)
+	return c.send(req)
 }
 
 // PostForm 发送表单POST请求
@@ -121,7 +188,69 @@ func (c *TokenHttpClient) PostForm(urlPath string, data map[string]string, heade
 	// 设置请求头
 	c.setHeaders(req, headers)
 
-	return c.client.Do(req)
+	return c.send(req)
+}
+
+// doWithBody 发送带可选JSON body的请求（PUT/PATCH/DELETE共用）
+func (c *TokenHttpClient) doWithBody(method, url string, data interface{}, headers map[string]string) (*http.Response, error) {
+	var body io.Reader
+	reqHeaders := make(map[string]string)
+
+	if data != nil {
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(jsonData)
+		reqHeaders["Content-Type"] = "application/json"
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range reqHeaders {
+		req.Header.Set(k, v)
+	}
+	c.setHeaders(req, headers)
+
+	return c.send(req)
+}
+
+// Put 发送PUT请求
+func (c *TokenHttpClient) Put(url string, data interface{}, headers map[string]string) (*http.Response, error) {
+	return c.doWithBody("PUT", url, data, headers)
+}
+
+// Patch 发送PATCH请求
+func (c *TokenHttpClient) Patch(url string, data interface{}, headers map[string]string) (*http.Response, error) {
+	return c.doWithBody("PATCH", url, data, headers)
+}
+
+// Delete 发送DELETE请求，data可选（部分接口允许DELETE携带JSON body）
+func (c *TokenHttpClient) Delete(url string, data interface{}, headers map[string]string) (*http.Response, error) {
+	return c.doWithBody("DELETE", url, data, headers)
+}
+
+// Head 发送HEAD请求
+func (c *TokenHttpClient) Head(url string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest("HEAD", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req, headers)
+	return c.send(req)
+}
+
+// Options 发送OPTIONS请求
+func (c *TokenHttpClient) Options(url string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest("OPTIONS", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req, headers)
+	return c.send(req)
 }
 
 // Close 关闭客户端（Go的http.Client不需要显式关闭，这里留空作为兼容）
@@ -132,9 +261,11 @@ func (c *TokenHttpClient) Close() {
 // 设置请求头
 func (c *TokenHttpClient) setHeaders(req *http.Request, headers map[string]string) {
 	// 设置客户端默认头
+	c.headerMu.RLock()
 	for k, v := range c.header {
 		req.Header.Set(k, v)
 	}
+	c.headerMu.RUnlock()
 
 	// 设置请求特定头（会覆盖默认头）
 	for k, v := range headers {
@@ -142,8 +273,21 @@ func (c *TokenHttpClient) setHeaders(req *http.Request, headers map[string]strin
 	}
 }
 
+// SetHeader 并发安全地设置/覆盖一个客户端默认头；RefreshToken等需要在运行期改写认证头的场景应使用它，
+// 而不是直接操作header这个map——它会被setHeaders从其他请求的goroutine中并发读取
+func (c *TokenHttpClient) SetHeader(key, value string) {
+	c.headerMu.Lock()
+	defer c.headerMu.Unlock()
+
+	if c.header == nil {
+		c.header = make(map[string]string)
+	}
+	c.header[key] = value
+}
+
 // OpenAPITokenClient OpenAPI Token认证客户端
 type OpenAPITokenClient struct {
+	tokenMu sync.RWMutex // 保护token；OpenAPITokenClientV2.RefreshToken/GetWithAuthType会并发读写它
 	token   string
 	baseURL string
 	client  *TokenHttpClient
@@ -159,7 +303,8 @@ func NewOpenAPITokenClient(baseURL, token string) *OpenAPITokenClient {
 		Header: headers,
 	}
 
-	client := NewTokenHttpClient(option)
+	// option不携带mTLS证书，NewTokenHttpClient在这种输入下不会返回error
+	client, _ := NewTokenHttpClient(option)
 
 	// 确保baseURL以斜杠结尾
 	if !strings.HasSuffix(baseURL, "/") {
@@ -228,11 +373,23 @@ func (c *OpenAPITokenClient) Put(urlPath string, data map[string]interface{}) (i
 		return nil, err
 	}
 
-	headers := map[string]string{
-		"X-HTTP-Method-Override": "PUT",
+	response, err := c.client.Put(fullURL, data, nil)
+	if err != nil {
+		return nil, err
 	}
+	defer response.Body.Close()
+
+	return c.handleResponse(response)
+}
 
-	response, err := c.client.PostJSON(fullURL, data, headers)
+// Patch 发送PATCH请求
+func (c *OpenAPITokenClient) Patch(urlPath string, data map[string]interface{}) (interface{}, error) {
+	fullURL, err := c.buildURL(urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.client.Patch(fullURL, data, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -248,11 +405,33 @@ func (c *OpenAPITokenClient) Delete(urlPath string) (interface{}, error) {
 		return nil, err
 	}
 
-	headers := map[string]string{
-		"X-HTTP-Method-Override": "DELETE",
+	response, err := c.client.Delete(fullURL, nil, nil)
+	if err != nil {
+		return nil, err
 	}
+	defer response.Body.Close()
 
-	response, err := c.client.Get(fullURL, headers)
+	return c.handleResponse(response)
+}
+
+// Head 发送HEAD请求，仅返回状态与响应头，无响应体
+func (c *OpenAPITokenClient) Head(urlPath string) (*http.Response, error) {
+	fullURL, err := c.buildURL(urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Head(fullURL, nil)
+}
+
+// Options 发送OPTIONS请求
+func (c *OpenAPITokenClient) Options(urlPath string) (interface{}, error) {
+	fullURL, err := c.buildURL(urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.client.Options(fullURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -299,14 +478,23 @@ func (c *OpenAPITokenClient) buildURL(urlPath string, params map[string]string)
 	return fullURL, nil
 }
 
-// 处理响应
-func (c *OpenAPITokenClient) handleResponse(response *http.Response) (interface{}, error) {
+// readRawBody 读取响应体并校验HTTP状态，>=400时返回错误；Get/Post/GetInto等共用
+func (c *OpenAPITokenClient) readRawBody(response *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
 	if response.StatusCode >= 400 {
-		body, _ := io.ReadAll(response.Body)
 		return nil, fmt.Errorf("HTTP %d: %s", response.StatusCode, string(body))
 	}
 
-	body, err := io.ReadAll(response.Body)
+	return body, nil
+}
+
+// 处理响应
+func (c *OpenAPITokenClient) handleResponse(response *http.Response) (interface{}, error) {
+	body, err := c.readRawBody(response)
 	if err != nil {
 		return nil, err
 	}
@@ -321,11 +509,40 @@ func (c *OpenAPITokenClient) handleResponse(response *http.Response) (interface{
 	return string(body), nil
 }
 
+// APIError 响应体信封中code不等于成功值时返回的业务错误
+type APIError struct {
+	Code    interface{}
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("接口返回错误 code=%v message=%s", e.Code, e.Message)
+}
+
+// EnvelopeConfig 描述响应体内嵌错误信封的字段名与成功值，例如{"code":0,"message":"","data":{}}
+type EnvelopeConfig struct {
+	CodeField    string
+	MessageField string
+	DataField    string
+	SuccessValue interface{} // 与CodeField对应的值做reflect.DeepEqual比较；JSON数字解析为float64
+}
+
+// DefaultEnvelopeConfig 返回最常见的{"code":0,"message":...,"data":...}信封约定
+func DefaultEnvelopeConfig() EnvelopeConfig {
+	return EnvelopeConfig{
+		CodeField:    "code",
+		MessageField: "message",
+		DataField:    "data",
+		SuccessValue: float64(0),
+	}
+}
+
 // OpenAPITokenClientV2 OpenAPI Token认证客户端（增强版）
 type OpenAPITokenClientV2 struct {
 	OpenAPITokenClient
 	authHeaderName   string
 	authHeaderFormat string
+	envelope         EnvelopeConfig
 }
 
 // NewOpenAPITokenClientV2 创建新的OpenAPITokenClientV2
@@ -354,7 +571,8 @@ func NewOpenAPITokenClientV2(baseURL, token string, timeout *int, verifySSL bool
 		ProxyAddress:   proxy,
 	}
 
-	client := NewTokenHttpClient(option)
+	// option不携带mTLS证书，NewTokenHttpClient在这种输入下不会返回error
+	client, _ := NewTokenHttpClient(option)
 
 	// 确保baseURL以斜杠结尾
 	if !strings.HasSuffix(baseURL, "/") {
@@ -369,33 +587,46 @@ func NewOpenAPITokenClientV2(baseURL, token string, timeout *int, verifySSL bool
 		},
 		authHeaderName:   authHeaderName,
 		authHeaderFormat: authHeaderFormat,
+		envelope:         DefaultEnvelopeConfig(),
 	}
 }
 
+// SetEnvelopeConfig 设置GetInto/PostInto解包响应体时使用的信封字段约定
+func (c *OpenAPITokenClientV2) SetEnvelopeConfig(envelope EnvelopeConfig) {
+	c.envelope = envelope
+}
+
 // RefreshToken 刷新认证令牌
 func (c *OpenAPITokenClientV2) RefreshToken(newToken string) {
+	c.tokenMu.Lock()
 	c.token = newToken
+	c.tokenMu.Unlock()
+
 	authHeaderValue := fmt.Sprintf(c.authHeaderFormat, newToken)
-	c.client.header[c.authHeaderName] = authHeaderValue
+	c.client.SetHeader(c.authHeaderName, authHeaderValue)
 }
 
 // GetWithAuthType 发送GET请求（支持多种认证类型）
 func (c *OpenAPITokenClientV2) GetWithAuthType(urlPath string, authType string) (interface{}, error) {
 	var headers map[string]string
 
+	c.tokenMu.RLock()
+	token := c.token
+	c.tokenMu.RUnlock()
+
 	switch authType {
 	case "bearer":
 		headers = map[string]string{
-			"Authorization": fmt.Sprintf("Bearer %s", c.token),
+			"Authorization": fmt.Sprintf("Bearer %s", token),
 		}
 	case "basic":
-		encodedToken := base64.StdEncoding.EncodeToString([]byte(c.token))
+		encodedToken := base64.StdEncoding.EncodeToString([]byte(token))
 		headers = map[string]string{
 			"Authorization": fmt.Sprintf("Basic %s", encodedToken),
 		}
 	default:
 		headers = map[string]string{
-			"token": c.token,
+			"token": token,
 		}
 	}
 
@@ -428,15 +659,15 @@ func (c *OpenAPITokenClientV2) Request(method, urlPath string, data map[string]i
 	case "POST":
 		response, err = c.client.PostJSON(fullURL, data, nil)
 	case "PUT":
-		headers := map[string]string{
-			"X-HTTP-Method-Override": "PUT",
-		}
-		response, err = c.client.PostJSON(fullURL, data, headers)
+		response, err = c.client.Put(fullURL, data, nil)
+	case "PATCH":
+		response, err = c.client.Patch(fullURL, data, nil)
 	case "DELETE":
-		headers := map[string]string{
-			"X-HTTP-Method-Override": "DELETE",
-		}
-		response, err = c.client.Get(fullURL, headers)
+		response, err = c.client.Delete(fullURL, data, nil)
+	case "HEAD":
+		response, err = c.client.Head(fullURL, nil)
+	case "OPTIONS":
+		response, err = c.client.Options(fullURL, nil)
 	default:
 		return nil, fmt.Errorf("不支持的HTTP方法: %s", method)
 	}
@@ -449,13 +680,95 @@ func (c *OpenAPITokenClientV2) Request(method, urlPath string, data map[string]i
 	return c.handleResponse(response)
 }
 
+// decodeEnvelope 按c.envelope的字段约定解析body：命中CodeField且不等于SuccessValue时返回*APIError，
+// 否则将DataField（或整个body，若不含信封字段）解析进v
+func (c *OpenAPITokenClientV2) decodeEnvelope(body []byte, v interface{}) error {
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		// 不是JSON对象（数组、裸值等），没有信封可言，整体解析进v
+		return json.Unmarshal(body, v)
+	}
+
+	code, hasCode := envelope[c.envelope.CodeField]
+	if !hasCode {
+		// 响应体不含约定的code字段，视为未使用信封，整体解析进v
+		return json.Unmarshal(body, v)
+	}
+
+	if !reflect.DeepEqual(code, c.envelope.SuccessValue) {
+		message, _ := envelope[c.envelope.MessageField].(string)
+		return &APIError{Code: code, Message: message}
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	data, hasData := envelope[c.envelope.DataField]
+	if !hasData {
+		return nil
+	}
+
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(dataBytes, v)
+}
+
+// GetInto 发送GET请求，按信封约定解包响应体并将data解析进v；code命中非成功值时返回*APIError
+func (c *OpenAPITokenClientV2) GetInto(urlPath string, params map[string]string, v interface{}) error {
+	fullURL, err := c.buildURL(urlPath, params)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.client.Get(fullURL, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := c.readRawBody(response)
+	if err != nil {
+		return err
+	}
+
+	return c.decodeEnvelope(body, v)
+}
+
+// PostInto 发送POST JSON请求，按信封约定解包响应体并将data解析进v；code命中非成功值时返回*APIError
+func (c *OpenAPITokenClientV2) PostInto(urlPath string, data map[string]interface{}, v interface{}) error {
+	fullURL, err := c.buildURL(urlPath, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.client.PostJSON(fullURL, data, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := c.readRawBody(response)
+	if err != nil {
+		return err
+	}
+
+	return c.decodeEnvelope(body, v)
+}
+
 // TokenManager Token管理器，支持自动刷新
 type TokenManager struct {
+	mu sync.Mutex // 保护currentToken/lastRefreshTime/refreshing；它们会被请求/响应拦截器从并发请求的goroutine中读写
+
 	baseURL         string
 	currentToken    string
 	refreshURL      string
 	refreshInterval int
 	lastRefreshTime int64
+	refreshing      bool // 刷新请求本身进行中时，拦截器不应再次触发刷新
 	client          *OpenAPITokenClientV2
 }
 
@@ -475,7 +788,7 @@ func NewTokenManager(baseURL, token, refreshURL string, refreshInterval int) *To
 		"Bearer {}",
 	)
 
-	return &TokenManager{
+	m := &TokenManager{
 		baseURL:         baseURL,
 		currentToken:    token,
 		refreshURL:      refreshURL,
@@ -483,6 +796,31 @@ func NewTokenManager(baseURL, token, refreshURL string, refreshInterval int) *To
 		lastRefreshTime: 0,
 		client:          client,
 	}
+
+	// 请求前按时间窗口主动刷新，覆盖所有verb（而不只是Get/Post）
+	client.client.AddRequestInterceptor(func(req *http.Request) error {
+		if !m.isRefreshing() && m.ShouldRefresh() {
+			m.Refresh()
+		}
+		return nil
+	})
+
+	// 响应401时说明令牌已经实际过期，被动刷新以便后续请求使用新令牌
+	client.client.AddResponseInterceptor(func(resp *http.Response) error {
+		if !m.isRefreshing() && resp.StatusCode == http.StatusUnauthorized {
+			m.Refresh()
+		}
+		return nil
+	})
+
+	return m
+}
+
+// isRefreshing 并发安全地读取是否已有刷新在进行中
+func (m *TokenManager) isRefreshing() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.refreshing
 }
 
 // ShouldRefresh 检查是否应该刷新令牌
@@ -491,8 +829,12 @@ func (m *TokenManager) ShouldRefresh() bool {
 		return false
 	}
 
+	m.mu.Lock()
+	lastRefreshTime := m.lastRefreshTime
+	m.mu.Unlock()
+
 	currentTime := time.Now().Unix()
-	return currentTime-m.lastRefreshTime > int64(m.refreshInterval)
+	return currentTime-lastRefreshTime > int64(m.refreshInterval)
 }
 
 // Refresh 刷新令牌
@@ -501,8 +843,23 @@ func (m *TokenManager) Refresh() bool {
 		return false
 	}
 
+	m.mu.Lock()
+	if m.refreshing {
+		m.mu.Unlock()
+		return false
+	}
+	m.refreshing = true
+	currentToken := m.currentToken
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		m.refreshing = false
+		m.mu.Unlock()
+	}()
+
 	data := map[string]interface{}{
-		"token": m.currentToken,
+		"token": currentToken,
 	}
 
 	response, err := m.client.Post(m.refreshURL, data)
@@ -522,30 +879,31 @@ func (m *TokenManager) Refresh() bool {
 		return false
 	}
 
-	m.currentToken = newToken
 	m.client.RefreshToken(newToken)
+
+	m.mu.Lock()
+	m.currentToken = newToken
 	m.lastRefreshTime = time.Now().Unix()
+	m.mu.Unlock()
+
 	return true
 }
 
-// Get 发送GET请求（支持自动刷新令牌）
-func (m *TokenManager) Get(urlPath string, autoRefresh bool) (interface{}, error) {
-	if autoRefresh && m.ShouldRefresh() {
-		m.Refresh()
-	}
-
+// Get 发送GET请求，令牌的主动/被动刷新均由拦截器自动处理
+func (m *TokenManager) Get(urlPath string) (interface{}, error) {
 	return m.client.Get(urlPath, nil)
 }
 
-// Post 发送POST请求（支持自动刷新令牌）
-func (m *TokenManager) Post(urlPath string, data map[string]interface{}, autoRefresh bool) (interface{}, error) {
-	if autoRefresh && m.ShouldRefresh() {
-		m.Refresh()
-	}
-
+// Post 发送POST请求，令牌的主动/被动刷新均由拦截器自动处理
+func (m *TokenManager) Post(urlPath string, data map[string]interface{}) (interface{}, error) {
 	return m.client.Post(urlPath, data)
 }
 
+// Request 发送任意verb的通用请求，同样享受拦截器自动刷新令牌
+func (m *TokenManager) Request(method, urlPath string, data map[string]interface{}, params map[string]string) (interface{}, error) {
+	return m.client.Request(method, urlPath, data, params)
+}
+
 // Close 关闭连接
 func (m *TokenManager) Close() {
 	m.client.Close()
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/proxy"
+)
+
+// configureProxy 按proxyAddress为transport配置代理拨号：
+// http://、https://直接设置transport.Proxy；socks5://、socks5h://通过golang.org/x/net/proxy
+// 构建拨号器并接管transport.DialContext；proxyAddress为空时退回http.ProxyFromEnvironment，
+// 尊重HTTP_PROXY/HTTPS_PROXY/NO_PROXY等环境变量。地址不含scheme时按http://补全，
+// 遇到无法解析或不支持的协议返回error而不是panic
+func configureProxy(transport *http.Transport, proxyAddress string) error {
+	if proxyAddress == "" {
+		transport.Proxy = http.ProxyFromEnvironment
+		return nil
+	}
+
+	if !strings.Contains(proxyAddress, "://") {
+		proxyAddress = "http://" + proxyAddress
+	}
+
+	proxyURL, err := url.Parse(proxyAddress)
+	if err != nil {
+		return fmt.Errorf("无效的代理地址: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https":
+		transport.Proxy = http.ProxyURL(proxyURL)
+	case "socks5", "socks5h":
+		dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
+		if err != nil {
+			return fmt.Errorf("构建SOCKS5拨号器失败: %w", err)
+		}
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if ctxDialer, ok := dialer.(proxy.ContextDialer); ok {
+				return ctxDialer.DialContext(ctx, network, addr)
+			}
+			return dialer.Dial(network, addr)
+		}
+	default:
+		return fmt.Errorf("不支持的代理协议: %s", proxyURL.Scheme)
+	}
+
+	return nil
+}
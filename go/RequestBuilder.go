@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestBuilder 链式构建并执行HTTP请求，补齐HttpClient缺失的PATCH/HEAD/OPTIONS
+// 以及逐次调用都要手写的重试/超时/钩子逻辑
+type RequestBuilder struct {
+	client *HttpClient
+
+	method  string
+	rawURL  string
+	query   url.Values
+	headers map[string]string
+	cookies []*http.Cookie
+
+	body        io.Reader
+	contentType string
+
+	ctx     context.Context
+	timeout time.Duration
+
+	retryCount       int
+	retryInterval    time.Duration
+	retryBackoff     float64
+	retryStatusCodes map[int]bool
+
+	onBeforeRequest []func(*http.Request)
+	onAfterResponse []func(*HTTPResponse)
+
+	buildErr error // 构建阶段（JSON编码、multipart等）遇到的第一个错误，Do()时统一返回
+}
+
+// NewRequestBuilder 基于已有HttpClient创建一个RequestBuilder
+func NewRequestBuilder(client *HttpClient) *RequestBuilder {
+	return &RequestBuilder{
+		client:  client,
+		method:  "GET",
+		query:   url.Values{},
+		headers: make(map[string]string),
+		ctx:     context.Background(),
+		retryStatusCodes: map[int]bool{
+			429: true, 500: true, 502: true, 503: true, 504: true,
+		},
+	}
+}
+
+// Method 设置HTTP方法，支持包括PATCH/HEAD/OPTIONS在内的任意动词
+func (b *RequestBuilder) Method(method string) *RequestBuilder {
+	b.method = strings.ToUpper(method)
+	return b
+}
+
+// URL 设置完整请求URL
+func (b *RequestBuilder) URL(rawURL string) *RequestBuilder {
+	b.rawURL = rawURL
+	return b
+}
+
+// Path 在已设置的URL基础上追加路径
+func (b *RequestBuilder) Path(path string) *RequestBuilder {
+	b.rawURL = strings.TrimRight(b.rawURL, "/") + "/" + strings.TrimLeft(path, "/")
+	return b
+}
+
+// Query 追加一个查询参数
+func (b *RequestBuilder) Query(key, value string) *RequestBuilder {
+	b.query.Add(key, value)
+	return b
+}
+
+// Header 设置一个请求头
+func (b *RequestBuilder) Header(key, value string) *RequestBuilder {
+	b.headers[key] = value
+	return b
+}
+
+// Cookie 追加一个请求Cookie
+func (b *RequestBuilder) Cookie(name, value string) *RequestBuilder {
+	b.cookies = append(b.cookies, &http.Cookie{Name: name, Value: value})
+	return b
+}
+
+// JSON 将data序列化为JSON作为请求体
+func (b *RequestBuilder) JSON(data interface{}) *RequestBuilder {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		b.buildErr = err
+		return b
+	}
+	b.body = bytes.NewBuffer(jsonData)
+	b.contentType = "application/json; charset=utf-8"
+	return b
+}
+
+// Form 将data编码为application/x-www-form-urlencoded请求体
+func (b *RequestBuilder) Form(data map[string]string) *RequestBuilder {
+	formData := url.Values{}
+	for k, v := range data {
+		formData.Set(k, v)
+	}
+	b.body = strings.NewReader(formData.Encode())
+	b.contentType = "application/x-www-form-urlencoded; charset=UTF-8"
+	return b
+}
+
+// Multipart 构建multipart/form-data请求体，files为文件部分，fields为普通表单字段
+func (b *RequestBuilder) Multipart(files []File, fields map[string]string) *RequestBuilder {
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+
+	for k, v := range fields {
+		if err := writer.WriteField(k, v); err != nil {
+			b.buildErr = err
+			return b
+		}
+	}
+
+	for i, file := range files {
+		part, err := writer.CreateFormFile(fmt.Sprintf("file_%d", i), file.Filename)
+		if err != nil {
+			b.buildErr = err
+			return b
+		}
+		if _, err := part.Write(file.Content); err != nil {
+			b.buildErr = err
+			return b
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		b.buildErr = err
+		return b
+	}
+
+	b.body = buf
+	b.contentType = writer.FormDataContentType()
+	return b
+}
+
+// WithContext 绑定请求的上下文，用于取消/超时传递
+func (b *RequestBuilder) WithContext(ctx context.Context) *RequestBuilder {
+	b.ctx = ctx
+	return b
+}
+
+// Timeout 设置本次请求的超时时间（通过context.WithTimeout实现）
+func (b *RequestBuilder) Timeout(d time.Duration) *RequestBuilder {
+	b.timeout = d
+	return b
+}
+
+// Retry 设置重试次数、基础间隔与指数退避系数；仅对网络错误或命中的状态码重试
+func (b *RequestBuilder) Retry(n int, interval time.Duration, backoff float64) *RequestBuilder {
+	b.retryCount = n
+	b.retryInterval = interval
+	b.retryBackoff = backoff
+	return b
+}
+
+// OnBeforeRequest 注册请求发出前的钩子，可用于签名、注入trace头等
+func (b *RequestBuilder) OnBeforeRequest(fn func(*http.Request)) *RequestBuilder {
+	b.onBeforeRequest = append(b.onBeforeRequest, fn)
+	return b
+}
+
+// OnAfterResponse 注册响应返回后的钩子，可用于日志、指标上报
+func (b *RequestBuilder) OnAfterResponse(fn func(*HTTPResponse)) *RequestBuilder {
+	b.onAfterResponse = append(b.onAfterResponse, fn)
+	return b
+}
+
+// Do 执行请求并返回封装后的HTTPResponse
+func (b *RequestBuilder) Do() (*HTTPResponse, error) {
+	if b.buildErr != nil {
+		return nil, b.buildErr
+	}
+
+	fullURL := b.rawURL
+	if len(b.query) > 0 {
+		sep := "?"
+		if strings.Contains(fullURL, "?") {
+			sep = "&"
+		}
+		fullURL += sep + b.query.Encode()
+	}
+
+	ctx := b.ctx
+	if b.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.timeout)
+		defer cancel()
+	}
+
+	headers := make(map[string]string, len(b.headers)+1)
+	for k, v := range b.headers {
+		headers[k] = v
+	}
+	if b.contentType != "" {
+		headers["Content-Type"] = b.contentType
+	}
+
+	maxAttempts := b.retryCount + 1
+	var lastResp *HTTPResponse
+	var lastErr error
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var bodyBytes []byte
+		if b.body != nil {
+			var err error
+			bodyBytes, err = io.ReadAll(b.body)
+			if err != nil {
+				return nil, err
+			}
+			b.body = bytes.NewReader(bodyBytes)
+		}
+
+		var reqBody io.Reader
+		if bodyBytes != nil {
+			reqBody = bytes.NewReader(bodyBytes)
+		}
+
+		req, err := b.client.buildRequest(b.method, fullURL, reqBody, headers)
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+		for _, cookie := range b.cookies {
+			req.AddCookie(cookie)
+		}
+
+		for _, hook := range b.onBeforeRequest {
+			hook(req)
+		}
+
+		resp, err := b.client.doRequest(req)
+		if err != nil {
+			lastErr = err
+			lastResp = nil
+		} else {
+			lastErr = nil
+			lastResp = resp
+			for _, hook := range b.onAfterResponse {
+				hook(resp)
+			}
+		}
+
+		if !b.shouldRetry(attempt, maxAttempts, lastResp, lastErr) {
+			break
+		}
+
+		if err := b.sleepBeforeRetry(ctx, attempt, lastResp); err != nil {
+			return nil, err
+		}
+	}
+
+	return lastResp, lastErr
+}
+
+// DoInto 执行请求并将JSON响应解析到v中，HTTP状态码>=400时返回错误而不解析（与HttpClient.DoInto一致）
+func (b *RequestBuilder) DoInto(v interface{}) error {
+	resp, err := b.Do()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Text())
+	}
+
+	return resp.JSON(v)
+}
+
+// shouldRetry 判断是否还需要重试：网络错误总是重试，状态码只在命中retryStatusCodes时重试
+func (b *RequestBuilder) shouldRetry(attempt, maxAttempts int, resp *HTTPResponse, err error) bool {
+	if attempt == maxAttempts-1 {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp != nil && b.retryStatusCodes[resp.StatusCode]
+}
+
+// sleepBeforeRetry 按指数退避等待，尊重Retry-After头与上下文取消
+func (b *RequestBuilder) sleepBeforeRetry(ctx context.Context, attempt int, resp *HTTPResponse) error {
+	wait := b.retryInterval
+	if b.retryBackoff > 0 {
+		for i := 0; i < attempt; i++ {
+			wait = time.Duration(float64(wait) * b.retryBackoff)
+		}
+	}
+
+	if resp != nil && resp.StatusCode == 429 {
+		if retryAfter, ok := resp.Headers["Retry-After"]; ok && len(retryAfter) > 0 {
+			if seconds, err := strconv.Atoi(retryAfter[0]); err == nil {
+				wait = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
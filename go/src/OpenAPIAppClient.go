@@ -0,0 +1,197 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// envPathPrefixes 调用方环境在路径上留下的前缀，签名前需要去除
+var envPathPrefixes = []string{"/release", "/test", "/prepub"}
+
+// OpenAPIAppClient 腾讯API网关"应用认证"风格的签名客户端
+type OpenAPIAppClient struct {
+	appKey    string
+	appSecret string
+	baseURL   string
+	source    string
+	client    *FastHttpClient
+}
+
+// NewOpenAPIAppClient 创建新的腾讯网关应用认证客户端
+func NewOpenAPIAppClient(baseURL, appKey, appSecret string) *OpenAPIAppClient {
+	// 确保baseURL以斜杠结尾
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	option := FastHttpClientOption{
+		Header: make(map[string]string),
+	}
+	client := NewFastHttpClient(option)
+
+	return &OpenAPIAppClient{
+		appKey:    appKey,
+		appSecret: appSecret,
+		baseURL:   baseURL,
+		source:    appKey,
+		client:    client,
+	}
+}
+
+// stripEnvPrefix 去除路径上的环境前缀（/release、/test、/prepub）
+func stripEnvPrefix(path string) string {
+	for _, prefix := range envPathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return strings.TrimPrefix(path, prefix)
+		}
+	}
+	return path
+}
+
+// sortedQuery 将查询参数按key字典序排序后编码
+func sortedQuery(params map[string]string) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := url.Values{}
+	for _, k := range keys {
+		values.Add(k, params[k])
+	}
+	return values.Encode()
+}
+
+// buildAuthHeadersApp 构建腾讯网关应用认证请求头
+func (c *OpenAPIAppClient) buildAuthHeadersApp(host string) map[string]string {
+	headers := make(map[string]string)
+
+	xDate := time.Now().UTC().Format(time.RFC1123)
+	xDate = strings.Replace(xDate, "UTC", "GMT", 1)
+
+	headers["X-Date"] = xDate
+	headers["Source"] = c.source
+	headers["Host"] = host
+	headers["Content-Type"] = "application/json"
+	headers["Accept"] = "application/json"
+
+	// 签名串按声明顺序拼接 headerName: headerValue，以\n连接
+	signHeaders := []string{"x-date", "source", "host"}
+	signLines := []string{
+		"x-date: " + xDate,
+		"source: " + c.source,
+		"host: " + host,
+	}
+	signText := strings.Join(signLines, "\n")
+
+	h := hmac.New(sha1.New, []byte(c.appSecret))
+	h.Write([]byte(signText))
+	signature := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	headers["Authorization"] = fmt.Sprintf(
+		`hmac id="%s", algorithm="hmac-sha1", headers="%s", signature="%s"`,
+		c.appKey, strings.Join(signHeaders, " "), signature,
+	)
+
+	return headers
+}
+
+// Get 发送GET请求（腾讯网关应用认证）
+func (c *OpenAPIAppClient) Get(urlPath string, params map[string]string) (interface{}, error) {
+	parsedBaseURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedPath, err := url.Parse(stripEnvPrefix(urlPath))
+	if err != nil {
+		return nil, err
+	}
+
+	fullURL := parsedBaseURL.ResolveReference(parsedPath).String()
+	if query := sortedQuery(params); query != "" {
+		fullURL += "?" + query
+	}
+
+	parsedFullURL, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, err
+	}
+
+	authHeaders := c.buildAuthHeadersApp(parsedFullURL.Host)
+
+	resp, err := c.client.Get(fullURL, authHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	statusCode := resp.StatusCode()
+	if statusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d: %s", statusCode, string(resp.Body()))
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return string(resp.Body()), nil
+	}
+
+	return result, nil
+}
+
+// Post 发送POST请求（腾讯网关应用认证）
+func (c *OpenAPIAppClient) Post(urlPath string, data map[string]interface{}) (interface{}, error) {
+	parsedBaseURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedPath, err := url.Parse(stripEnvPrefix(urlPath))
+	if err != nil {
+		return nil, err
+	}
+
+	fullURL := parsedBaseURL.ResolveReference(parsedPath).String()
+
+	parsedFullURL, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, err
+	}
+
+	authHeaders := c.buildAuthHeadersApp(parsedFullURL.Host)
+
+	resp, err := c.client.PostJSON(fullURL, data, authHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	statusCode := resp.StatusCode()
+	if statusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d: %s", statusCode, string(resp.Body()))
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return string(resp.Body()), nil
+	}
+
+	return result, nil
+}
+
+// Close 关闭连接
+func (c *OpenAPIAppClient) Close() {
+	if c.client != nil {
+		c.client.Close()
+	}
+}
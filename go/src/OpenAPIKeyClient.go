@@ -1,29 +1,30 @@
 package main
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
+	"context"
 	"crypto/tls"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"net"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/valyala/fasthttp"
+	"golang.org/x/net/proxy"
 )
 
 // FastHttpClientOption 定义HTTP客户端配置选项
 type FastHttpClientOption struct {
-	Header         map[string]string
-	SocketTimeout  int // 毫秒
-	ConnectTimeout int // 毫秒
-	IgnoreSSL      bool
-	ProxyAddress   string
+	Header              map[string]string
+	SocketTimeout       int // 毫秒
+	ConnectTimeout      int // 毫秒
+	IgnoreSSL           bool
+	ProxyAddress        string        // socks5://或socks5h://形式的代理地址；http(s)://不被支持，会打印错误并不使用代理
+	MaxConnsPerHost     int           // 每个host的最大连接数，0使用fasthttp默认值
+	MaxIdleConnDuration time.Duration // 空闲连接最长保留时长，0使用fasthttp默认值
+	Proxy               proxy.Dialer  // 可选，直接提供代理拨号器，优先级高于ProxyAddress
 }
 
 // FastHttpClient HTTP客户端
@@ -32,25 +33,76 @@ type FastHttpClient struct {
 	header map[string]string
 }
 
+// dnsScatterDialer 返回"DNS打散"风格的拨号函数：对同一域名解析出的多条A记录，
+// 从随机偏移开始依次尝试并在失败时fallback到下一个，使域名背后的多个实例都能分到流量
+func dnsScatterDialer(connectTimeout time.Duration) fasthttp.DialFunc {
+	return func(addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return fasthttp.DialTimeout(addr, connectTimeout)
+		}
+
+		ipAddrs, err := net.DefaultResolver.LookupIPAddr(context.Background(), host)
+		if err != nil || len(ipAddrs) == 0 {
+			return fasthttp.DialTimeout(addr, connectTimeout)
+		}
+
+		offset := rand.Intn(len(ipAddrs))
+		var lastErr error
+		for i := 0; i < len(ipAddrs); i++ {
+			ip := ipAddrs[(offset+i)%len(ipAddrs)]
+			conn, dialErr := fasthttp.DialTimeout(net.JoinHostPort(ip.IP.String(), port), connectTimeout)
+			if dialErr == nil {
+				return conn, nil
+			}
+			lastErr = dialErr
+		}
+		return nil, lastErr
+	}
+}
+
+// buildProxyDialer 根据ProxyAddress构建SOCKS5代理拨号器；proxy.FromURL只识别socks5/socks5h，
+// http(s)://等其他协议返回error
+func buildProxyDialer(proxyAddress string) (proxy.Dialer, error) {
+	proxyURL, err := url.Parse(proxyAddress)
+	if err != nil {
+		return nil, fmt.Errorf("无效的代理地址: %w", err)
+	}
+	return proxy.FromURL(proxyURL, proxy.Direct)
+}
+
 // NewFastHttpClient 创建新的HTTP客户端
 func NewFastHttpClient(option FastHttpClientOption) *FastHttpClient {
+	connectTimeout := time.Duration(option.ConnectTimeout) * time.Millisecond
+
 	client := &fasthttp.Client{
-		ReadTimeout:  time.Duration(option.SocketTimeout) * time.Millisecond,
-		WriteTimeout: time.Duration(option.SocketTimeout) * time.Millisecond,
-		Dial: func(addr string) (net.Conn, error) {
-			return fasthttp.DialTimeout(addr, time.Duration(option.ConnectTimeout)*time.Millisecond)
-		},
+		ReadTimeout:         time.Duration(option.SocketTimeout) * time.Millisecond,
+		WriteTimeout:        time.Duration(option.SocketTimeout) * time.Millisecond,
+		MaxConnsPerHost:     option.MaxConnsPerHost,
+		MaxIdleConnDuration: option.MaxIdleConnDuration,
 		TLSConfig: &tls.Config{
 			InsecureSkipVerify: option.IgnoreSSL,
 		},
 	}
 
-	// 代理配置暂时注释掉，因为fasthttp.NewDialer在某些版本中可能不可用
-	// if option.ProxyAddress != "" {
-	// 	client.Dial = func(addr string) (net.Conn, error) {
-	// 		return fasthttp.Dial(addr)
-	// 	}
-	// }
+	proxyDialer := option.Proxy
+	if proxyDialer == nil && option.ProxyAddress != "" {
+		d, err := buildProxyDialer(option.ProxyAddress)
+		if err != nil {
+			fmt.Printf("配置代理失败，将不使用代理: %v\n", err)
+		} else {
+			proxyDialer = d
+		}
+	}
+
+	switch {
+	case proxyDialer != nil:
+		client.Dial = func(addr string) (net.Conn, error) {
+			return proxyDialer.Dial("tcp", addr)
+		}
+	default:
+		client.Dial = dnsScatterDialer(connectTimeout)
+	}
 
 	return &FastHttpClient{
 		client: client,
@@ -159,6 +211,59 @@ func (c *FastHttpClient) PostForm(url string, data map[string]string, headers ma
 	return resp, nil
 }
 
+// doWithBody 发送带可选JSON body的请求（PUT/DELETE/PATCH共用）
+func (c *FastHttpClient) doWithBody(method, url string, data interface{}, headers map[string]string) (*fasthttp.Response, error) {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod(method)
+
+	// 设置默认头
+	for k, v := range c.header {
+		req.Header.Set(k, v)
+	}
+
+	// 设置请求头
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	// 序列化数据（可选）
+	if data != nil {
+		req.Header.SetContentType("application/json")
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBody(jsonData)
+	}
+
+	if err := c.client.Do(req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Put 发送PUT请求
+func (c *FastHttpClient) Put(url string, data interface{}, headers map[string]string) (*fasthttp.Response, error) {
+	return c.doWithBody("PUT", url, data, headers)
+}
+
+// Delete 发送DELETE请求
+func (c *FastHttpClient) Delete(url string, data interface{}, headers map[string]string) (*fasthttp.Response, error) {
+	return c.doWithBody("DELETE", url, data, headers)
+}
+
+// Patch 发送PATCH请求
+func (c *FastHttpClient) Patch(url string, data interface{}, headers map[string]string) (*fasthttp.Response, error) {
+	return c.doWithBody("PATCH", url, data, headers)
+}
+
 // Close 关闭客户端
 func (c *FastHttpClient) Close() {
 	// fasthttp.Client 不需要显式关闭
@@ -166,10 +271,9 @@ func (c *FastHttpClient) Close() {
 
 // OpenAPIKeyClient OpenAPI密钥认证客户端
 type OpenAPIKeyClient struct {
-	apiKey    string
-	apiSecret string
-	baseURL   string
-	client    *FastHttpClient
+	baseURL string
+	client  *FastHttpClient
+	signer  *HMACSHA256BodySigner
 }
 
 // NewOpenAPIKeyClient 创建新的OpenAPI客户端
@@ -185,41 +289,17 @@ func NewOpenAPIKeyClient(baseURL, apiKey, apiSecret string) *OpenAPIKeyClient {
 	client := NewFastHttpClient(option)
 
 	return &OpenAPIKeyClient{
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
-		baseURL:   baseURL,
-		client:    client,
+		baseURL: baseURL,
+		client:  client,
+		signer:  &HMACSHA256BodySigner{APIKey: apiKey, APISecret: apiSecret},
 	}
 }
 
-// generateSignature 生成HMAC-SHA256签名
-func (c *OpenAPIKeyClient) generateSignature(text string) string {
-	h := hmac.New(sha256.New, []byte(c.apiSecret))
-	h.Write([]byte(text))
-	return hex.EncodeToString(h.Sum(nil))
-}
-
-// buildAuthHeaders 构建认证请求头
+// buildAuthHeaders 构建认证请求头，签名算法委托给HMACSHA256BodySigner（见Signer.go），
+// 与OpenAPIKeyClientV2共用同一套Signer实现而不是各自重写一遍HMAC拼接逻辑
 func (c *OpenAPIKeyClient) buildAuthHeaders(requestBody string) map[string]string {
-	headers := make(map[string]string)
-
-	// 时间戳（毫秒）
-	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
-
-	// 随机数（Nonce）
-	nonce := strings.ReplaceAll(uuid.New().String(), "-", "")
-
-	// 签名计算：apiKey + timestamp + nonce + body
-	signText := c.apiKey + timestamp + nonce + requestBody
-	signature := c.generateSignature(signText)
-
-	// 添加认证头
-	headers["X-Api-Key"] = c.apiKey
-	headers["X-Timestamp"] = timestamp
-	headers["X-Nonce"] = nonce
-	headers["X-Signature"] = signature
+	headers, _ := c.signer.Sign(&SignableRequest{Body: requestBody}) // HMACSHA256BodySigner.Sign不会返回error
 	headers["Content-Type"] = "application/json"
-
 	return headers
 }
 
@@ -369,10 +449,13 @@ func (c *OpenAPIKeyClient) Close() {
 
 // OpenAPIKeyClientV2 OpenAPI密钥认证客户端（增强版）
 type OpenAPIKeyClientV2 struct {
-	apiKey    string
-	apiSecret string
-	baseURL   string
-	client    *FastHttpClient
+	baseURL          string
+	client           *FastHttpClient
+	signer           *HMACSHA256MethodPathSigner
+	retryPolicy      *RetryPolicy
+	nonceCache       *NonceCache
+	breaker          *CircuitBreaker
+	onNonceCollision func(nonce string) // nonce命中nonceCache时触发，默认仅打印警告
 }
 
 // NewOpenAPIKeyClientV2 创建新的增强版OpenAPI客户端
@@ -411,56 +494,115 @@ func NewOpenAPIKeyClientV2(baseURL, apiKey, apiSecret string, timeout *int, veri
 	client := NewFastHttpClient(option)
 
 	return &OpenAPIKeyClientV2{
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
-		baseURL:   baseURL,
-		client:    client,
+		baseURL:    baseURL,
+		client:     client,
+		signer:     &HMACSHA256MethodPathSigner{APIKey: apiKey, APISecret: apiSecret},
+		nonceCache: NewNonceCache(1024),
+		breaker:    NewCircuitBreaker(5, 30*time.Second),
 	}
 }
 
-// generateSignatureV2 生成增强版签名
-func (c *OpenAPIKeyClientV2) generateSignatureV2(method, path, timestamp, nonce, body string) string {
-	// 计算body哈希
-	bodyHash := ""
-	if body != "" {
-		h := sha256.New()
-		h.Write([]byte(body))
-		bodyHash = hex.EncodeToString(h.Sum(nil))
+// NewOpenAPIKeyClientV2WithOption 创建增强版OpenAPI客户端，允许高吞吐场景下直接调优
+// 底层FastHttpClient的连接池参数（MaxConnsPerHost、MaxIdleConnDuration、Proxy）
+func NewOpenAPIKeyClientV2WithOption(baseURL, apiKey, apiSecret string, option FastHttpClientOption) *OpenAPIKeyClientV2 {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
 	}
+	if option.Header == nil {
+		option.Header = make(map[string]string)
+	}
+	option.Header["User-Agent"] = "OpenAPI-Go-Client/1.0"
+	option.Header["Accept"] = "application/json"
+
+	return &OpenAPIKeyClientV2{
+		baseURL:    baseURL,
+		client:     NewFastHttpClient(option),
+		signer:     &HMACSHA256MethodPathSigner{APIKey: apiKey, APISecret: apiSecret},
+		nonceCache: NewNonceCache(1024),
+		breaker:    NewCircuitBreaker(5, 30*time.Second),
+	}
+}
+
+// SetRetryPolicy 为客户端启用重试策略；传nil则关闭重试（默认行为）
+func (c *OpenAPIKeyClientV2) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// SetNonceCollisionHandler 注册nonce复用回调，用于并发场景下发现签名被意外复用；
+// 不注册时requestOnce退回打印一条警告，保证该信息始终可被观察到
+func (c *OpenAPIKeyClientV2) SetNonceCollisionHandler(handler func(nonce string)) {
+	c.onNonceCollision = handler
+}
 
-	// 构建签名字符串
-	signText := fmt.Sprintf("%s%s%s%s%s%s", strings.ToUpper(method), path, c.apiKey, timestamp, nonce, bodyHash)
+// NonceSeenBefore 对底层nonceCache做一次只读查询，不会把nonce记入缓存；
+// 真正发送请求时的复用检测由requestOnce通过nonceCache.SeenBefore完成并记录。
+// 之前这里误用了会写入缓存的SeenBefore，调用方若用它做生成nonce前的防御性检查，
+// 会把从未真正发出的nonce计入缓存，导致后续真实发送同一nonce时被误判为复用
+func (c *OpenAPIKeyClientV2) NonceSeenBefore(nonce string) bool {
+	if c.nonceCache == nil {
+		return false
+	}
+	return c.nonceCache.Peek(nonce)
+}
 
-	// 生成HMAC-SHA256签名
-	h := hmac.New(sha256.New, []byte(c.apiSecret))
-	h.Write([]byte(signText))
-	return hex.EncodeToString(h.Sum(nil))
+// generateSignatureV2 生成增强版签名，签名算法委托给HMACSHA256MethodPathSigner（见Signer.go）；
+// timestamp/nonce以参数形式传入而非现生成，便于按固定输入做可重现的校验
+func (c *OpenAPIKeyClientV2) generateSignatureV2(method, path, timestamp, nonce, body string) string {
+	return c.signer.sign(method, path, timestamp, nonce, body)
 }
 
-// buildAuthHeadersV2 构建增强版认证请求头
+// buildAuthHeadersV2 构建增强版认证请求头，签名算法委托给HMACSHA256MethodPathSigner
 func (c *OpenAPIKeyClientV2) buildAuthHeadersV2(method, path, body string) map[string]string {
-	headers := make(map[string]string)
+	headers, _ := c.signer.Sign(&SignableRequest{Method: method, Path: path, Body: body}) // HMACSHA256MethodPathSigner.Sign不会返回error
+	headers["Content-Type"] = "application/json"
+	return headers
+}
 
-	// 时间戳（秒，整数）
-	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+// requestOnce 执行一次请求尝试：重新生成nonce/timestamp/signature，发送并返回原始响应
+func (c *OpenAPIKeyClientV2) requestOnce(method, fullURL, path string, data map[string]interface{}) (*fasthttp.Response, error) {
+	requestBody := ""
+	if data != nil {
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = string(jsonData)
+	}
 
-	// 随机数（Nonce）
-	nonce := strings.ReplaceAll(uuid.New().String(), "-", "")
+	// 每次尝试都重新构建认证头，nonce/timestamp/signature不可跨次复用
+	authHeaders := c.buildAuthHeadersV2(method, path, requestBody)
+	if nonce := authHeaders["X-Nonce"]; nonce != "" && c.nonceCache != nil {
+		if c.nonceCache.SeenBefore(nonce) {
+			if c.onNonceCollision != nil {
+				c.onNonceCollision(nonce)
+			} else {
+				fmt.Printf("检测到Nonce复用: %s\n", nonce)
+			}
+		}
+	}
 
-	// 生成签名
-	signature := c.generateSignatureV2(method, path, timestamp, nonce, body)
+	var resp *fasthttp.Response
+	var err error
 
-	// 添加认证头
-	headers["X-Api-Key"] = c.apiKey
-	headers["X-Timestamp"] = timestamp
-	headers["X-Nonce"] = nonce
-	headers["X-Signature"] = signature
-	headers["Content-Type"] = "application/json"
+	switch strings.ToUpper(method) {
+	case "GET":
+		resp, err = c.client.Get(fullURL, authHeaders)
+	case "POST":
+		resp, err = c.client.PostJSON(fullURL, data, authHeaders)
+	case "PUT":
+		resp, err = c.client.Put(fullURL, data, authHeaders)
+	case "DELETE":
+		resp, err = c.client.Delete(fullURL, data, authHeaders)
+	case "PATCH":
+		resp, err = c.client.Patch(fullURL, data, authHeaders)
+	default:
+		return nil, fmt.Errorf("不支持的HTTP方法: %s", method)
+	}
 
-	return headers
+	return resp, err
 }
 
-// Request 发送通用请求（带签名认证）
+// Request 发送通用请求（带签名认证），若设置了RetryPolicy则在网络/可重试状态码上自动重试
 func (c *OpenAPIKeyClientV2) Request(method, urlPath string, data map[string]interface{}, params map[string]string) (interface{}, error) {
 	// 构建完整URL
 	parsedBaseURL, err := url.Parse(c.baseURL)
@@ -484,7 +626,7 @@ func (c *OpenAPIKeyClientV2) Request(method, urlPath string, data map[string]int
 		fullURL += "?" + values.Encode()
 	}
 
-	// 解析URL以获取路径部分（用于签名）
+	// 解析URL以获取路径部分（用于签名）和host（用于熔断）
 	parsedURL, err := url.Parse(fullURL)
 	if err != nil {
 		return nil, err
@@ -493,40 +635,49 @@ func (c *OpenAPIKeyClientV2) Request(method, urlPath string, data map[string]int
 	if parsedURL.RawQuery != "" {
 		path += "?" + parsedURL.RawQuery
 	}
+	host := parsedURL.Host
 
-	// 准备请求体
-	requestBody := ""
-	if data != nil {
-		jsonData, err := json.Marshal(data)
-		if err != nil {
-			return nil, err
+	policy := c.retryPolicy
+	maxAttempts := 1
+	if policy != nil && policy.shouldRetryMethod(strings.ToUpper(method)) {
+		maxAttempts = policy.MaxAttempts
+		if maxAttempts < 1 {
+			maxAttempts = 1
 		}
-		requestBody = string(jsonData)
 	}
 
-	// 构建认证头
-	authHeaders := c.buildAuthHeadersV2(method, path, requestBody)
-
 	var resp *fasthttp.Response
+	var lastErr error
 
-	// 根据方法发送请求
-	switch strings.ToUpper(method) {
-	case "GET":
-		resp, err = c.client.Get(fullURL, authHeaders)
-	case "POST":
-		resp, err = c.client.PostJSON(fullURL, data, authHeaders)
-	case "PUT":
-		// 注意：这里简化为POST，实际应用中应实现PUT方法
-		resp, err = c.client.PostJSON(fullURL, data, authHeaders)
-	case "DELETE":
-		// 注意：这里简化为GET，实际应用中应实现DELETE方法
-		resp, err = c.client.Get(fullURL, authHeaders)
-	default:
-		return nil, fmt.Errorf("不支持的HTTP方法: %s", method)
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if c.breaker != nil && !c.breaker.Allow(host) {
+			return nil, fmt.Errorf("熔断器已跳闸，host=%s暂时不可用", host)
+		}
+
+		resp, lastErr = c.requestOnce(method, fullURL, path, data)
+
+		statusCode := -1
+		if lastErr == nil {
+			statusCode = resp.StatusCode()
+		}
+		if c.breaker != nil {
+			c.breaker.RecordResult(host, statusCode)
+		}
+
+		if lastErr == nil && statusCode < 400 {
+			break
+		}
+
+		retryable := lastErr != nil || (policy != nil && policy.RetryableStatusCodes[statusCode])
+		if attempt == maxAttempts-1 || !retryable || policy == nil {
+			break
+		}
+
+		time.Sleep(policy.backoff(attempt))
 	}
 
-	if err != nil {
-		return nil, err
+	if lastErr != nil {
+		return nil, lastErr
 	}
 
 	// 检查响应状态
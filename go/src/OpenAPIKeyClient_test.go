@@ -0,0 +1,73 @@
+package main
+
+import "testing"
+
+// TestGenerateSignatureV2DiffersByMethod 验证PUT/DELETE/PATCH生成的签名串各不相同，
+// 即签名确实把真实的HTTP方法纳入计算，而不是像override方案那样退化成同一个伪装方法
+func TestGenerateSignatureV2DiffersByMethod(t *testing.T) {
+	client := NewOpenAPIKeyClientV2("http://example.com", "demoKey", "demoSecret", nil, true, nil)
+	defer client.Close()
+
+	path := "/openapi/asset/connection/item"
+	timestamp := "1700000000"
+	nonce := "nonce"
+
+	signatures := make(map[string]string)
+	for _, method := range []string{"PUT", "DELETE", "PATCH"} {
+		signatures[method] = client.generateSignatureV2(method, path, timestamp, nonce, "")
+	}
+
+	for m1, s1 := range signatures {
+		for m2, s2 := range signatures {
+			if m1 != m2 && s1 == s2 {
+				t.Errorf("签名未区分方法: %s 和 %s 生成了相同的签名 %s", m1, m2, s1)
+			}
+		}
+	}
+}
+
+// TestGenerateSignatureV2Reproducible 验证相同的method/path/timestamp/nonce/body输入
+// 总是得到相同的签名，且任一输入变化都会让签名变化
+func TestGenerateSignatureV2Reproducible(t *testing.T) {
+	client := NewOpenAPIKeyClientV2("http://example.com", "demoKey", "demoSecret", nil, true, nil)
+	defer client.Close()
+
+	base := client.generateSignatureV2("PATCH", "/openapi/asset/connection/item", "1700000000", "nonce", "")
+	again := client.generateSignatureV2("PATCH", "/openapi/asset/connection/item", "1700000000", "nonce", "")
+	if base != again {
+		t.Fatalf("相同输入生成了不同签名: %s != %s", base, again)
+	}
+
+	cases := map[string]string{
+		"PUT":  client.generateSignatureV2("PUT", "/openapi/asset/connection/item", "1700000000", "nonce", ""),
+		"ts":   client.generateSignatureV2("PATCH", "/openapi/asset/connection/item", "1700000001", "nonce", ""),
+		"nc":   client.generateSignatureV2("PATCH", "/openapi/asset/connection/item", "1700000000", "nonce2", ""),
+		"body": client.generateSignatureV2("PATCH", "/openapi/asset/connection/item", "1700000000", "nonce", `{"a":1}`),
+	}
+	for name, sig := range cases {
+		if sig == base {
+			t.Errorf("改变 %s 后签名未发生变化", name)
+		}
+	}
+}
+
+// TestNonceSeenBeforeDoesNotPoisonCache 验证NonceSeenBefore是只读查询：
+// 重复调用它不会把nonce计入缓存，也不会影响requestOnce后续真实记录同一nonce时的判断
+func TestNonceSeenBeforeDoesNotPoisonCache(t *testing.T) {
+	client := NewOpenAPIKeyClientV2("http://example.com", "demoKey", "demoSecret", nil, true, nil)
+	defer client.Close()
+
+	if client.NonceSeenBefore("n1") {
+		t.Fatalf("首次查询不应报告已使用")
+	}
+	if client.NonceSeenBefore("n1") {
+		t.Fatalf("重复查询不应把nonce计入缓存")
+	}
+
+	if client.nonceCache.SeenBefore("n1") {
+		t.Fatalf("nonceCache此前不应被NonceSeenBefore污染，第一次真实记录应返回false")
+	}
+	if !client.nonceCache.SeenBefore("n1") {
+		t.Fatalf("真实记录后，第二次应检测到复用")
+	}
+}
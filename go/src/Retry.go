@@ -0,0 +1,192 @@
+package main
+
+import (
+	"container/list"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RetryPolicy 控制OpenAPIKeyClientV2.Request的重试行为
+type RetryPolicy struct {
+	MaxAttempts          int           // 含首次请求在内的最大尝试次数，<=1表示不重试
+	BaseBackoff          time.Duration // 首次重试的基础退避时长，之后按2^n指数增长
+	MaxBackoff           time.Duration // 退避时长上限
+	Jitter               time.Duration // 在退避时长基础上叠加的随机抖动上限
+	RetryableStatusCodes map[int]bool  // 命中这些状态码才重试
+	AllowNonIdempotent   bool          // 是否允许对非GET/HEAD方法重试（幂等性由调用方保证）
+}
+
+// DefaultRetryPolicy 返回一组保守的默认重试参数
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 200 * time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+		Jitter:      100 * time.Millisecond,
+		RetryableStatusCodes: map[int]bool{
+			429: true, 500: true, 502: true, 503: true, 504: true,
+		},
+		AllowNonIdempotent: false,
+	}
+}
+
+// shouldRetryMethod 判断该方法在当前策略下是否允许重试
+func (p *RetryPolicy) shouldRetryMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD":
+		return true
+	default:
+		return p.AllowNonIdempotent
+	}
+}
+
+// backoff 计算第attempt次重试（从0开始）前的等待时长
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseBackoff << uint(attempt)
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d += time.Duration(rand.Int63n(int64(p.Jitter) + 1))
+	}
+	return d
+}
+
+// NonceCache 最近使用Nonce的内存LRU，供调用方在并发场景下检测意外复用
+type NonceCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+// NewNonceCache 创建容量为capacity的NonceCache
+func NewNonceCache(capacity int) *NonceCache {
+	if capacity <= 0 {
+		capacity = 1024
+	}
+	return &NonceCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// SeenBefore 记录一个nonce，如果此前已经出现过则返回true（复用告警），否则记录并返回false
+func (c *NonceCache) SeenBefore(nonce string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[nonce]; ok {
+		c.order.MoveToFront(elem)
+		return true
+	}
+
+	elem := c.order.PushFront(nonce)
+	c.entries[nonce] = elem
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(string))
+		}
+	}
+
+	return false
+}
+
+// Peek 只读检查一个nonce是否已经出现过，既不写入也不刷新LRU顺序；
+// 与SeenBefore不同，调用Peek不会把从未真正发送过的nonce计入缓存，适合调用方在生成nonce前做防御性检查
+func (c *NonceCache) Peek(nonce string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.entries[nonce]
+	return ok
+}
+
+// circuitState 熔断器状态
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// hostCircuit 单个host的熔断状态
+type hostCircuit struct {
+	state           circuitState
+	consecutiveFail int
+	openedAt        time.Time
+}
+
+// CircuitBreaker 按host维度的半开熔断器：连续N次5xx后短路后续调用
+type CircuitBreaker struct {
+	mu            sync.Mutex
+	failThreshold int
+	cooldown      time.Duration
+	hosts         map[string]*hostCircuit
+}
+
+// NewCircuitBreaker 创建熔断器，failThreshold次连续5xx后跳闸，cooldown后进入半开试探
+func NewCircuitBreaker(failThreshold int, cooldown time.Duration) *CircuitBreaker {
+	if failThreshold <= 0 {
+		failThreshold = 5
+	}
+	return &CircuitBreaker{
+		failThreshold: failThreshold,
+		cooldown:      cooldown,
+		hosts:         make(map[string]*hostCircuit),
+	}
+}
+
+// Allow 判断是否允许向该host发起请求；熔断跳闸期间返回false
+func (b *CircuitBreaker) Allow(host string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	hc, ok := b.hosts[host]
+	if !ok {
+		return true
+	}
+
+	switch hc.state {
+	case circuitOpen:
+		if time.Since(hc.openedAt) >= b.cooldown {
+			hc.state = circuitHalfOpen
+			return true
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordResult 上报一次请求的结果，statusCode<0表示网络错误
+func (b *CircuitBreaker) RecordResult(host string, statusCode int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	hc, ok := b.hosts[host]
+	if !ok {
+		hc = &hostCircuit{}
+		b.hosts[host] = hc
+	}
+
+	failed := statusCode < 0 || statusCode >= 500
+
+	if !failed {
+		hc.state = circuitClosed
+		hc.consecutiveFail = 0
+		return
+	}
+
+	hc.consecutiveFail++
+	if hc.state == circuitHalfOpen || hc.consecutiveFail >= b.failThreshold {
+		hc.state = circuitOpen
+		hc.openedAt = time.Now()
+	}
+}
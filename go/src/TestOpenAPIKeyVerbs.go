@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// demoVerbSignatures 验证PUT/DELETE/PATCH签名串使用真实的方法名而非伪装的POST/GET
+func demoVerbSignatures() {
+	client := NewOpenAPIKeyClientV2("http://10.0.0.132", "demoKey", "demoSecret", nil, true, nil)
+	defer client.Close()
+
+	data := map[string]interface{}{"name": "书架A"}
+
+	for _, method := range []string{"PUT", "DELETE", "PATCH"} {
+		signature := client.generateSignatureV2(method, "/openapi/asset/connection/item", "1700000000", "nonce", "")
+		fmt.Printf("%s 签名: %s\n", method, signature)
+	}
+
+	result, err := client.Request("PATCH", "/openapi/asset/connection/item", data, nil)
+	if err != nil {
+		fmt.Printf("请求失败: %v\n", err)
+		return
+	}
+	fmt.Printf("%v\n", result)
+}
+
+// demoRetryPolicy 演示为OpenAPIKeyClientV2开启重试策略后，GET请求在5xx上会自动重试并重新签名
+func demoRetryPolicy() {
+	client := NewOpenAPIKeyClientV2("http://10.0.0.132", "demoKey", "demoSecret", nil, true, nil)
+	defer client.Close()
+
+	client.SetRetryPolicy(DefaultRetryPolicy())
+
+	result, err := client.Request("GET", "/openapi/asset/connection/getData", nil, map[string]string{"rid": "tushuguan"})
+	if err != nil {
+		fmt.Printf("请求失败: %v\n", err)
+		return
+	}
+	fmt.Printf("%v\n", result)
+}
+
+// demoConnectionPoolTuning 演示为高吞吐场景调优连接池参数：限制每host连接数、空闲连接时长
+func demoConnectionPoolTuning() {
+	client := NewOpenAPIKeyClientV2WithOption("http://10.0.0.132", "demoKey", "demoSecret", FastHttpClientOption{
+		SocketTimeout:       5000,
+		ConnectTimeout:      2000,
+		MaxConnsPerHost:     256,
+		MaxIdleConnDuration: 30 * time.Second,
+	})
+	defer client.Close()
+
+	result, err := client.Request("GET", "/openapi/asset/connection/getData", nil, map[string]string{"rid": "tushuguan"})
+	if err != nil {
+		fmt.Printf("请求失败: %v\n", err)
+		return
+	}
+	fmt.Printf("%v\n", result)
+}
+
+// main 依次跑三个独立的演示场景；原先每个场景各自一个文件、各自一个main()，
+// 同处package main下会"main redeclared"编译失败，合并为一个入口
+func main() {
+	demoVerbSignatures()
+	demoRetryPolicy()
+	demoConnectionPoolTuning()
+}
@@ -0,0 +1,215 @@
+// Package stress 提供一个类似go-stress-testing的压测/冒烟测试挖具，
+// 用于对任意已签名的OpenAPI客户端（OpenAPIKeyClientV2、Signer等）做并发打流量。
+package stress
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Target 是压测对象的最小抽象，调用方用自己的客户端（比如OpenAPIKeyClientV2.Request）实现它，
+// 这样stress包不需要依赖具体客户端实现。
+type Target interface {
+	Do(method, path string, body []byte, params map[string]string) (statusCode int, respBody []byte, err error)
+}
+
+// RequestTemplate 描述压测过程中重复发送的请求
+type RequestTemplate struct {
+	Method string
+	Path   string
+	Body   []byte
+	Params map[string]string
+}
+
+// Verifier 对每次响应做业务级校验，比如状态码匹配、JSON字段相等
+type Verifier func(statusCode int, respBody []byte) bool
+
+// StatusCodeVerifier 返回一个只校验状态码的Verifier
+func StatusCodeVerifier(expect int) Verifier {
+	return func(statusCode int, _ []byte) bool {
+		return statusCode == expect
+	}
+}
+
+// Runner 压测执行器配置
+type Runner struct {
+	Target      Target
+	Template    RequestTemplate
+	Concurrency int           // 并发worker数
+	Total       int           // 总请求数
+	Interval    time.Duration // 打印中间进度的间隔，<=0表示只在结束时打印一次
+	Verifier    Verifier      // 可选，nil表示只以HTTP状态码<400判定成功
+}
+
+// result 单次请求的原始观测数据，由worker发往collector
+type result struct {
+	statusCode int
+	latency    time.Duration
+	bytesIn    int
+	bytesOut   int
+	success    bool
+}
+
+// Report 压测汇总报告
+type Report struct {
+	Total           int
+	Success         int
+	Failed          int
+	QPS             float64
+	P50             time.Duration
+	P90             time.Duration
+	P99             time.Duration
+	StatusHistogram map[int]int
+	BytesIn         int64
+	BytesOut        int64
+	Elapsed         time.Duration
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf(
+		"total=%d success=%d failed=%d qps=%.1f p50=%s p90=%s p99=%s bytesIn=%d bytesOut=%d elapsed=%s",
+		r.Total, r.Success, r.Failed, r.QPS, r.P50, r.P90, r.P99, r.BytesIn, r.BytesOut, r.Elapsed,
+	)
+}
+
+// Run 按配置启动worker并发打流量，返回最终汇总报告
+func (r *Runner) Run() *Report {
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make(chan result, concurrency*2)
+	var dispatched int64
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				if atomic.AddInt64(&dispatched, 1) > int64(r.Total) {
+					return
+				}
+				results <- r.doOne()
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return r.collect(results, start)
+}
+
+// doOne 执行单次请求并转换为result
+func (r *Runner) doOne() result {
+	reqStart := time.Now()
+	statusCode, body, err := r.Target.Do(r.Template.Method, r.Template.Path, r.Template.Body, r.Template.Params)
+	latency := time.Since(reqStart)
+
+	success := err == nil
+	if success {
+		if r.Verifier != nil {
+			success = r.Verifier(statusCode, body)
+		} else {
+			success = statusCode < 400
+		}
+	}
+
+	return result{
+		statusCode: statusCode,
+		latency:    latency,
+		bytesIn:    len(body),
+		bytesOut:   len(r.Template.Body),
+		success:    success,
+	}
+}
+
+// collect 消费worker产出的result，按Interval打印中间进度，返回最终报告
+func (r *Runner) collect(results <-chan result, start time.Time) *Report {
+	latencies := make([]time.Duration, 0, r.Total)
+	histogram := make(map[int]int)
+	var success, failed int
+	var bytesIn, bytesOut int64
+
+	var ticker *time.Ticker
+	var tickCh <-chan time.Time
+	if r.Interval > 0 {
+		ticker = time.NewTicker(r.Interval)
+		tickCh = ticker.C
+		defer ticker.Stop()
+	}
+
+	done := false
+	for !done {
+		select {
+		case res, ok := <-results:
+			if !ok {
+				done = true
+				break
+			}
+			latencies = append(latencies, res.latency)
+			histogram[res.statusCode]++
+			bytesIn += int64(res.bytesIn)
+			bytesOut += int64(res.bytesOut)
+			if res.success {
+				success++
+			} else {
+				failed++
+			}
+		case <-tickCh:
+			elapsed := time.Since(start)
+			qps := float64(success+failed) / elapsed.Seconds()
+			fmt.Printf("[stress] progress=%d/%d qps=%.1f elapsed=%s\n", success+failed, r.Total, qps, elapsed)
+		}
+	}
+
+	elapsed := time.Since(start)
+	total := success + failed
+
+	report := &Report{
+		Total:           total,
+		Success:         success,
+		Failed:          failed,
+		StatusHistogram: histogram,
+		BytesIn:         bytesIn,
+		BytesOut:        bytesOut,
+		Elapsed:         elapsed,
+	}
+	if elapsed > 0 {
+		report.QPS = float64(total) / elapsed.Seconds()
+	}
+	report.P50, report.P90, report.P99 = percentiles(latencies)
+	fmt.Printf("[stress] %s\n", report)
+
+	return report
+}
+
+// percentiles 对延迟样本排序后取p50/p90/p99
+func percentiles(latencies []time.Duration) (p50, p90, p99 time.Duration) {
+	if len(latencies) == 0 {
+		return 0, 0, 0
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	at := func(pct float64) time.Duration {
+		idx := int(pct * float64(len(sorted)))
+		if idx >= len(sorted) {
+			idx = len(sorted) - 1
+		}
+		return sorted[idx]
+	}
+
+	return at(0.50), at(0.90), at(0.99)
+}